@@ -0,0 +1,185 @@
+// Package cache provides a memory-bounded, least-recently-used cache for
+// byte-ish payloads (rendered readable HTML, raw feed bodies) that are
+// expensive to regenerate but too large to keep around unconditionally.
+package cache
+
+import (
+	"container/list"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// envMemoryLimit, when set (bytes), overrides the auto-detected budget.
+// Mirrors Hugo's HUGO_MEMORYLIMIT for operators who want to pin it.
+const envMemoryLimit = "GIST_CACHE_MEMORY_LIMIT"
+
+// Sizer reports the approximate number of bytes an entry occupies, so the
+// cache can evict by memory pressure rather than by entry count.
+type Sizer interface {
+	CacheSize() int
+}
+
+type entry struct {
+	key       string
+	value     any
+	size      int
+	expiresAt time.Time // zero means no expiry
+}
+
+// Cache is an LRU cache bounded by total approximate byte size rather than
+// entry count. It is safe for concurrent use.
+type Cache struct {
+	mu    sync.Mutex
+	limit int64
+	used  int64
+
+	ll    *list.List // most-recently-used at the front
+	items map[string]*list.Element
+
+	hits, misses, evictions int64
+}
+
+// New creates a Cache with the given byte budget. A limit of 0 auto-detects
+// a budget from the environment (see DetectMemoryLimit).
+func New(limit int64) *Cache {
+	if limit <= 0 {
+		limit = DetectMemoryLimit()
+	}
+	return &Cache{
+		limit: limit,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached value for key, or false if it is absent or has
+// expired. An expired entry is evicted on access.
+func (c *Cache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	ent := el.Value.(*entry)
+	if !ent.expiresAt.IsZero() && time.Now().After(ent.expiresAt) {
+		c.removeElement(el)
+		c.misses++
+		return nil, false
+	}
+
+	c.ll.MoveToFront(el)
+	c.hits++
+	return ent.value, true
+}
+
+// Set inserts or updates key, evicting least-recently-used entries until the
+// cache is back under budget. ttl of zero means the entry never expires on
+// its own (it can still be evicted under memory pressure).
+func (c *Cache) Set(key string, value any, size int, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		ent := el.Value.(*entry)
+		c.used += int64(size - ent.size)
+		ent.value = value
+		ent.size = size
+		ent.expiresAt = expiresAt
+		c.ll.MoveToFront(el)
+	} else {
+		ent := &entry{key: key, value: value, size: size, expiresAt: expiresAt}
+		el := c.ll.PushFront(ent)
+		c.items[key] = el
+		c.used += int64(size)
+	}
+
+	c.evictLocked()
+}
+
+// Delete removes key from the cache, if present.
+func (c *Cache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+}
+
+// SetLimit adjusts the byte budget, immediately evicting if the cache is
+// now over the new limit. Used to halve the budget under memory pressure.
+func (c *Cache) SetLimit(limit int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.limit = limit
+	c.evictLocked()
+}
+
+func (c *Cache) evictLocked() {
+	for c.used > c.limit {
+		back := c.ll.Back()
+		if back == nil {
+			break
+		}
+		c.removeElement(back)
+		c.evictions++
+	}
+}
+
+func (c *Cache) removeElement(el *list.Element) {
+	ent := el.Value.(*entry)
+	c.ll.Remove(el)
+	delete(c.items, ent.key)
+	c.used -= int64(ent.size)
+}
+
+// Stats is a point-in-time snapshot for observability.
+type Stats struct {
+	Entries   int
+	UsedBytes int64
+	Limit     int64
+	Hits      int64
+	Misses    int64
+	Evictions int64
+}
+
+// Stats returns a snapshot of the cache's current size and hit/miss counts.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{
+		Entries:   c.ll.Len(),
+		UsedBytes: c.used,
+		Limit:     c.limit,
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+	}
+}
+
+// DetectMemoryLimit returns GIST_CACHE_MEMORY_LIMIT if set, otherwise a
+// quarter of detected system memory (via /proc/meminfo, falling back to the
+// Go runtime's own memory stats when that isn't available).
+func DetectMemoryLimit() int64 {
+	if v := lookupEnvBytes(envMemoryLimit); v > 0 {
+		return v
+	}
+	if total := systemMemory(); total > 0 {
+		return total / 4
+	}
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	if mem.Sys > 0 {
+		return int64(mem.Sys) / 4
+	}
+	return 256 << 20 // 256MB fallback when nothing else is available
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"runtime"
+	"time"
+)
+
+// pressureCheckInterval is how often MonitorPressure samples RSS.
+const pressureCheckInterval = 30 * time.Second
+
+// pressureThreshold is how close (as a fraction of total system memory)
+// process RSS has to get before the cache halves its own budget to give
+// the rest of the process room to breathe. The cache's own limit is
+// typically only a quarter of system memory, so it is not itself a useful
+// pressure signal: checking against it would trip almost immediately and
+// never recover, regardless of actual memory pressure.
+const pressureThreshold = 0.9
+
+// minPressureLimit is the smallest budget halving under pressure will ever
+// leave the cache with. Without a floor, repeated halving under sustained
+// pressure (…→2→1→0 via integer division) eventually hits exactly zero,
+// at which point evictLocked drops every entry on insert and the cache is
+// permanently disabled for the rest of the process's life. Stopping here
+// instead leaves it degraded but still useful.
+const minPressureLimit = 4 << 20 // 4MB
+
+// MonitorPressure starts a background goroutine that halves c's memory
+// budget whenever this process's RSS approaches total system memory, and
+// stops when stop is closed. It never grows the budget back on its own;
+// restart the process (or call SetLimit explicitly) to recover it.
+func (c *Cache) MonitorPressure(stop <-chan struct{}) {
+	go func() {
+		ticker := time.NewTicker(pressureCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				c.checkPressure()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+func (c *Cache) checkPressure() {
+	total := systemMemory()
+	if total <= 0 {
+		// No /proc/meminfo (non-Linux): fall back to the runtime's own
+		// view of reserved memory as a rough proxy.
+		var mem runtime.MemStats
+		runtime.ReadMemStats(&mem)
+		total = int64(mem.Sys)
+	}
+	if total <= 0 {
+		return
+	}
+
+	rss := processRSS()
+	if rss <= 0 {
+		var mem runtime.MemStats
+		runtime.ReadMemStats(&mem)
+		rss = int64(mem.Sys)
+	}
+
+	c.mu.Lock()
+	limit := c.limit
+	c.mu.Unlock()
+
+	if limit <= minPressureLimit {
+		return
+	}
+	if float64(rss) >= float64(total)*pressureThreshold {
+		halved := limit / 2
+		if halved < minPressureLimit {
+			halved = minPressureLimit
+		}
+		c.SetLimit(halved)
+	}
+}
@@ -0,0 +1,93 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"gist/backend/internal/model"
+)
+
+type appriseNotifier struct {
+	endpoint string
+	urls     []string
+}
+
+func newAppriseNotifier(cfg Config) Notifier {
+	return &appriseNotifier{endpoint: cfg.Endpoint, urls: cfg.AppriseURLs}
+}
+
+type appriseRequest struct {
+	URLs  string `json:"urls"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Type  string `json:"type"`
+}
+
+// Notify POSTs a single JSON payload to the configured Apprise API
+// endpoint, which fans it out to every URL Apprise is configured with.
+func (n *appriseNotifier) Notify(ctx context.Context, feed model.Feed, entries []model.Entry) error {
+	if n.endpoint == "" {
+		return fmt.Errorf("apprise: no endpoint configured")
+	}
+
+	payload := appriseRequest{
+		URLs:  joinURLs(n.urls),
+		Title: fmt.Sprintf("%s: %d new entr%s", feed.Title, len(entries), pluralY(len(entries))),
+		Body:  summarizeEntries(entries),
+		Type:  "info",
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("apprise: encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("apprise: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("apprise: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("apprise: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func joinURLs(urls []string) string {
+	out := ""
+	for i, u := range urls {
+		if i > 0 {
+			out += ","
+		}
+		out += u
+	}
+	return out
+}
+
+func pluralY(n int) string {
+	if n == 1 {
+		return "y"
+	}
+	return "ies"
+}
+
+func summarizeEntries(entries []model.Entry) string {
+	body := ""
+	for i, e := range entries {
+		if i > 0 {
+			body += "\n"
+		}
+		body += e.Title
+	}
+	return body
+}
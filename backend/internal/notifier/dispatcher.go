@@ -0,0 +1,170 @@
+package notifier
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"gist/backend/internal/model"
+)
+
+// batchWindow is how long the dispatcher waits after the first new entry
+// for a feed before flushing, so a feed that imports 50 items at once
+// produces one notification instead of 50.
+const batchWindow = 30 * time.Second
+
+type job struct {
+	feed    model.Feed
+	entries []model.Entry
+}
+
+// batch accumulates entries for one feed between flushes. The feed is kept
+// alongside the entries (rather than just its ID) so a graceful-shutdown
+// flush can still address the notification by the feed's real title/URL.
+type batch struct {
+	feed    model.Feed
+	entries []model.Entry
+}
+
+// Dispatcher batches newly-ingested entries per feed and delivers them to a
+// Notifier once batchWindow has elapsed since the batch's first entry.
+// Callers enqueue via Enqueue after committing new entries; RefreshService
+// is the typical caller. If rules is non-nil, entries are filtered against
+// the feed's effective notification rules (keywords, muted folders) before
+// they're ever added to a batch.
+type Dispatcher struct {
+	notifier Notifier
+	rules    RulesProvider
+	queue    chan job
+
+	mu      sync.Mutex
+	pending map[int64]*batch
+	timers  map[int64]*time.Timer
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewDispatcher starts a Dispatcher backed by notifier, consuming jobs from
+// a buffered channel so RefreshService never blocks on delivery. rules may
+// be nil, in which case every entry is notified unfiltered.
+func NewDispatcher(notifier Notifier, rules RulesProvider) *Dispatcher {
+	d := &Dispatcher{
+		notifier: notifier,
+		rules:    rules,
+		queue:    make(chan job, 64),
+		pending:  make(map[int64]*batch),
+		timers:   make(map[int64]*time.Timer),
+		stopCh:   make(chan struct{}),
+	}
+	d.wg.Add(1)
+	go d.run()
+	return d
+}
+
+// Enqueue filters entries against feed's effective notification rules and
+// adds whatever survives to feed's pending batch, starting the batch
+// window timer if one isn't already running for this feed.
+func (d *Dispatcher) Enqueue(ctx context.Context, feed model.Feed, entries []model.Entry) {
+	entries = d.applyRules(ctx, feed, entries)
+	if len(entries) == 0 {
+		return
+	}
+	select {
+	case d.queue <- job{feed: feed, entries: entries}:
+	case <-d.stopCh:
+	}
+}
+
+func (d *Dispatcher) applyRules(ctx context.Context, feed model.Feed, entries []model.Entry) []model.Entry {
+	if d.rules == nil {
+		return entries
+	}
+	rules, err := d.rules.Rules(ctx, feed.ID)
+	if err != nil {
+		log.Printf("notifier: load rules for feed %d: %v", feed.ID, err)
+		return entries
+	}
+	return rules.Apply(feed, entries)
+}
+
+// Stop flushes any pending batches immediately and stops the dispatcher.
+func (d *Dispatcher) Stop() {
+	close(d.stopCh)
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) run() {
+	defer d.wg.Done()
+	for {
+		select {
+		case j := <-d.queue:
+			d.addToBatch(j)
+		case <-d.stopCh:
+			d.flushAll()
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) addToBatch(j job) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	feedID := j.feed.ID
+	if existing, ok := d.pending[feedID]; ok {
+		existing.entries = append(existing.entries, j.entries...)
+	} else {
+		d.pending[feedID] = &batch{feed: j.feed, entries: j.entries}
+	}
+
+	if _, scheduled := d.timers[feedID]; scheduled {
+		return
+	}
+	d.timers[feedID] = time.AfterFunc(batchWindow, func() {
+		d.flush(feedID)
+	})
+}
+
+func (d *Dispatcher) flush(feedID int64) {
+	d.mu.Lock()
+	b := d.pending[feedID]
+	delete(d.pending, feedID)
+	delete(d.timers, feedID)
+	d.mu.Unlock()
+
+	if b == nil || len(b.entries) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := d.notifier.Notify(ctx, b.feed, b.entries); err != nil {
+		log.Printf("notifier: deliver batch for feed %d: %v", feedID, err)
+	}
+}
+
+func (d *Dispatcher) flushAll() {
+	d.mu.Lock()
+	pending := d.pending
+	timers := d.timers
+	d.pending = make(map[int64]*batch)
+	d.timers = make(map[int64]*time.Timer)
+	d.mu.Unlock()
+
+	for _, t := range timers {
+		t.Stop()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	for feedID, b := range pending {
+		if b == nil || len(b.entries) == 0 {
+			continue
+		}
+		if err := d.notifier.Notify(ctx, b.feed, b.entries); err != nil {
+			log.Printf("notifier: deliver batch for feed %d: %v", feedID, err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Package notifier fans out newly-ingested entries to user-configured
+// sinks (Apprise, generic webhooks, ntfy.sh) once a feed refresh commits
+// them, batching per feed so a flood of new items becomes one notification
+// instead of one-post-per-item.
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"gist/backend/internal/model"
+)
+
+// Notifier delivers a batch of newly-seen entries for a single feed to one
+// configured sink.
+type Notifier interface {
+	Notify(ctx context.Context, feed model.Feed, entries []model.Entry) error
+}
+
+// Sink identifies which Notifier implementation a FeedNotificationSetting
+// targets.
+type Sink string
+
+const (
+	SinkApprise Sink = "apprise"
+	SinkWebhook Sink = "webhook"
+	SinkNtfy    Sink = "ntfy"
+)
+
+// New builds the Notifier for the given sink kind and config. Unknown
+// sink kinds return an error rather than a no-op, so a typo in persisted
+// settings surfaces instead of silently dropping notifications.
+func New(sink Sink, cfg Config) (Notifier, error) {
+	switch sink {
+	case SinkApprise:
+		return newAppriseNotifier(cfg), nil
+	case SinkWebhook:
+		return newWebhookNotifier(cfg), nil
+	case SinkNtfy:
+		return newNtfyNotifier(cfg), nil
+	default:
+		return nil, fmt.Errorf("notifier: unknown sink %q", sink)
+	}
+}
+
+// Config holds the union of fields any sink might need. Each implementation
+// only reads the fields it cares about.
+type Config struct {
+	// Endpoint is the Apprise API URL, the webhook URL, or the ntfy.sh
+	// topic URL, depending on Sink.
+	Endpoint string
+	// AppriseURLs are the target service URLs Apprise should notify
+	// (e.g. "discord://...", "mailto://..."), as used by SinkApprise.
+	AppriseURLs []string
+	// Headers are extra HTTP headers for SinkWebhook.
+	Headers map[string]string
+	// BodyTemplate is a Go text/template string for SinkWebhook; {{.Feed}}
+	// and {{.Entries}} are available.
+	BodyTemplate string
+}
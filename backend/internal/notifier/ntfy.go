@@ -0,0 +1,43 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gist/backend/internal/model"
+)
+
+type ntfyNotifier struct {
+	topicURL string
+}
+
+func newNtfyNotifier(cfg Config) Notifier {
+	return &ntfyNotifier{topicURL: cfg.Endpoint}
+}
+
+// Notify posts a plain-text message to the ntfy.sh topic URL, using the
+// feed title as the notification title header.
+func (n *ntfyNotifier) Notify(ctx context.Context, feed model.Feed, entries []model.Entry) error {
+	if n.topicURL == "" {
+		return fmt.Errorf("ntfy: no topic URL configured")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.topicURL, strings.NewReader(summarizeEntries(entries)))
+	if err != nil {
+		return fmt.Errorf("ntfy: build request: %w", err)
+	}
+	req.Header.Set("Title", fmt.Sprintf("%s: %d new entr%s", feed.Title, len(entries), pluralY(len(entries))))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("ntfy: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("ntfy: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
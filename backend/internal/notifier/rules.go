@@ -0,0 +1,113 @@
+package notifier
+
+import (
+	"context"
+	"strings"
+
+	"gist/backend/internal/model"
+)
+
+// Rules are the user-configured filters applied before an entry reaches a
+// sink: "only keywords X", "mute folder Y". Global rules and a feed's own
+// rules are combined by RulesProvider before Apply ever sees them.
+type Rules struct {
+	// KeywordGroups, if non-empty, restricts notifications to entries that
+	// match every group: an entry must contain at least one keyword from
+	// each group (OR within a group, AND across groups). A single source
+	// of keywords (e.g. a feed's own list) is one group; Merge adds the
+	// other source as an additional group rather than folding the two
+	// lists together, so a global "only keywords X" allow-list narrows
+	// what a feed's own keywords can let through instead of broadening it.
+	KeywordGroups [][]string
+	// MutedFolderIDs drops all entries belonging to a feed in one of these
+	// folders, regardless of keywords.
+	MutedFolderIDs []int64
+}
+
+// NewKeywordRule builds a Rules with a single keyword group, for callers
+// that only have one source of keywords (e.g. constructing per-feed or
+// global rules individually before Merge combines them).
+func NewKeywordRule(keywords []string) Rules {
+	if len(keywords) == 0 {
+		return Rules{}
+	}
+	return Rules{KeywordGroups: [][]string{keywords}}
+}
+
+// Apply filters entries down to the ones that should actually be notified
+// for feed, given r. A muted folder drops every entry; each non-empty
+// keyword group (when set) must have at least one match per entry.
+func (r Rules) Apply(feed model.Feed, entries []model.Entry) []model.Entry {
+	if r.mutes(feed) {
+		return nil
+	}
+	if len(r.KeywordGroups) == 0 {
+		return entries
+	}
+
+	var kept []model.Entry
+	for _, entry := range entries {
+		if r.matchesAllGroups(entry) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+func (r Rules) mutes(feed model.Feed) bool {
+	if feed.FolderID == nil {
+		return false
+	}
+	for _, id := range r.MutedFolderIDs {
+		if id == *feed.FolderID {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAllGroups requires entry to match at least one keyword in every
+// non-empty group. An empty group imposes no restriction of its own.
+func (r Rules) matchesAllGroups(entry model.Entry) bool {
+	for _, group := range r.KeywordGroups {
+		if len(group) == 0 {
+			continue
+		}
+		if !matchesAnyKeyword(entry, group) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesAnyKeyword(entry model.Entry, keywords []string) bool {
+	haystack := strings.ToLower(entry.Title + " " + entry.Summary)
+	for _, keyword := range keywords {
+		if keyword == "" {
+			continue
+		}
+		if strings.Contains(haystack, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge combines global rules with a feed's own rules: muted folders union,
+// and each side's keyword groups are kept separate (not flattened into one
+// list) so a global "only keywords X" allow-list constrains what a feed's
+// own keywords can let through — an entry must satisfy both sides, not
+// either one.
+func Merge(global, perFeed Rules) Rules {
+	return Rules{
+		KeywordGroups:  append(append([][]string{}, global.KeywordGroups...), perFeed.KeywordGroups...),
+		MutedFolderIDs: append(append([]int64{}, global.MutedFolderIDs...), perFeed.MutedFolderIDs...),
+	}
+}
+
+// RulesProvider resolves the effective notification rules for a feed,
+// combining global and per-feed settings as persisted by the settings CRUD
+// endpoints.
+type RulesProvider interface {
+	Rules(ctx context.Context, feedID int64) (Rules, error)
+}
@@ -0,0 +1,71 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"text/template"
+
+	"gist/backend/internal/model"
+)
+
+type webhookNotifier struct {
+	endpoint string
+	headers  map[string]string
+	body     *template.Template
+}
+
+func newWebhookNotifier(cfg Config) Notifier {
+	tmplSrc := cfg.BodyTemplate
+	if tmplSrc == "" {
+		tmplSrc = defaultWebhookTemplate
+	}
+	// Parsing errors surface at Notify time via the nil template rather
+	// than panicking during construction, since settings come from users.
+	tmpl, err := template.New("webhook").Parse(tmplSrc)
+	if err != nil {
+		tmpl = nil
+	}
+	return &webhookNotifier{endpoint: cfg.Endpoint, headers: cfg.Headers, body: tmpl}
+}
+
+const defaultWebhookTemplate = `{{.Feed.Title}}: {{len .Entries}} new entries`
+
+type webhookPayload struct {
+	Feed    model.Feed
+	Entries []model.Entry
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, feed model.Feed, entries []model.Entry) error {
+	if n.endpoint == "" {
+		return fmt.Errorf("webhook: no endpoint configured")
+	}
+	if n.body == nil {
+		return fmt.Errorf("webhook: invalid body template")
+	}
+
+	var buf bytes.Buffer
+	if err := n.body.Execute(&buf, webhookPayload{Feed: feed, Entries: entries}); err != nil {
+		return fmt.Errorf("webhook: render body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.endpoint, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		return fmt.Errorf("webhook: build request: %w", err)
+	}
+	for k, v := range n.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Package opml parses and renders OPML 2.0 subscription lists, the format
+// most feed readers use for import/export.
+package opml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// Document is the root <opml> element.
+type Document struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    Head     `xml:"head"`
+	Body    Body     `xml:"body"`
+}
+
+// Head holds the export's metadata.
+type Head struct {
+	Title        string `xml:"title,omitempty"`
+	DateCreated  string `xml:"dateCreated,omitempty"`
+	DateModified string `xml:"dateModified,omitempty"`
+}
+
+// Body wraps the top-level outlines.
+type Body struct {
+	Outlines []Outline `xml:"outline"`
+}
+
+// Outline is a single <outline> element: either a folder (with nested
+// Outlines) or a feed (with XMLURL set).
+type Outline struct {
+	Text    string `xml:"text,attr"`
+	Title   string `xml:"title,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	XMLURL  string `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL string `xml:"htmlUrl,attr,omitempty"`
+	// Category holds the OPML "category" attribute, a comma-separated list
+	// of "/"-delimited folder paths (e.g. "/Tech/Go,/Personal"). Feedly,
+	// NewsBlur, and Inoreader exports use this flat form instead of
+	// nesting feeds inside folder outlines.
+	Category string    `xml:"category,attr,omitempty"`
+	Outlines []Outline `xml:"outline,omitempty"`
+}
+
+// Parse reads an OPML document from r.
+func Parse(r io.Reader) (Document, error) {
+	var doc Document
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return Document{}, fmt.Errorf("opml: decode: %w", err)
+	}
+	return doc, nil
+}
+
+// Encode renders doc as an OPML document with an XML declaration.
+func Encode(doc Document) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("opml: encode: %w", err)
+	}
+	return buf.Bytes(), nil
+}
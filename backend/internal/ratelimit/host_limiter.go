@@ -0,0 +1,69 @@
+// Package ratelimit provides a simple per-host token bucket so that
+// concurrent work against many different URLs never hammers a single host.
+package ratelimit
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// HostLimiter ensures requests to the same host are spaced at least
+// minInterval apart, regardless of how many callers are racing to reach it.
+// It is safe for concurrent use and is meant to be shared across anything
+// that makes outbound HTTP requests on behalf of the same process (the
+// scheduler's feed fetcher and the readability fetcher, for example), so a
+// feed and an on-demand "read this article" request for the same domain
+// still queue behind each other.
+type HostLimiter struct {
+	minInterval time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+// NewHostLimiter creates a HostLimiter that allows at most one request per
+// host every minInterval.
+func NewHostLimiter(minInterval time.Duration) *HostLimiter {
+	return &HostLimiter{
+		minInterval: minInterval,
+		next:        make(map[string]time.Time),
+	}
+}
+
+// Wait blocks until it is this host's turn, or ctx is done. rawURL may be a
+// full URL or a bare host; only the host portion is used as the bucket key.
+func (l *HostLimiter) Wait(ctx context.Context, rawURL string) error {
+	host := hostOf(rawURL)
+
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		readyAt, scheduled := l.next[host]
+		if !scheduled || !now.Before(readyAt) {
+			l.next[host] = now.Add(l.minInterval)
+			l.mu.Unlock()
+			return nil
+		}
+		wait := readyAt.Sub(now)
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+			// loop again: another goroutine may have claimed this slot
+			// while we were waiting.
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+func hostOf(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return rawURL
+}
@@ -6,30 +6,79 @@ import (
 	"sync"
 	"time"
 
+	"gist/backend/internal/model"
+	"gist/backend/internal/ratelimit"
 	"gist/backend/internal/service"
 )
 
+// defaultHostInterval is how far apart requests to the same host are
+// spaced when the caller doesn't provide a limiter of its own.
+const defaultHostInterval = 2 * time.Second
+
+// RefreshResult reports the outcome of refreshing a single feed, so callers
+// (the API, for "last refresh status") can observe per-feed failures
+// instead of only an aggregate log line.
+type RefreshResult struct {
+	FeedID int64
+	Err    error
+}
+
+// Scheduler periodically asks the RefreshService which feeds are due and
+// refreshes them concurrently across a worker pool. It no longer refreshes
+// every feed on every tick: each feed tracks its own NextUpdate, so interval
+// only controls how often the scheduler checks for due feeds, not how often
+// any single feed is fetched.
 type Scheduler struct {
 	refreshService service.RefreshService
 	interval       time.Duration
-	stopCh         chan struct{}
-	wg             sync.WaitGroup
+	workers        int
+	limiter        *ratelimit.HostLimiter
+
+	results chan RefreshResult
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
 }
 
-func New(refreshService service.RefreshService, interval time.Duration) *Scheduler {
+// New creates a Scheduler that checks for due feeds every interval and
+// processes up to workers of them concurrently. limiter is shared with
+// anything else fetching on this process's behalf (the readability
+// service, in particular) so feeds and on-demand article fetches from the
+// same host never overlap; pass nil to get a scheduler-private limiter.
+func New(refreshService service.RefreshService, interval time.Duration, workers int, limiter *ratelimit.HostLimiter) *Scheduler {
+	if workers < 1 {
+		workers = 1
+	}
+	if limiter == nil {
+		limiter = ratelimit.NewHostLimiter(defaultHostInterval)
+	}
 	return &Scheduler{
 		refreshService: refreshService,
 		interval:       interval,
+		workers:        workers,
+		limiter:        limiter,
+		results:        make(chan RefreshResult, workers),
 		stopCh:         make(chan struct{}),
 	}
 }
 
+// Results returns the channel of per-feed refresh outcomes. Delivery is
+// best-effort: the channel is buffered but not infinite, and a result is
+// dropped rather than blocking a refresh worker if nothing is reading it
+// (the scheduler still logs failures either way, so nothing is lost from
+// the log, only from this channel).
+func (s *Scheduler) Results() <-chan RefreshResult {
+	return s.results
+}
+
 func (s *Scheduler) Start() {
 	s.wg.Add(1)
 	go s.run()
-	log.Printf("scheduler started with interval %v", s.interval)
+	log.Printf("scheduler started with interval %v, %d worker(s)", s.interval, s.workers)
 }
 
+// Stop signals the scheduler to shut down and waits for any in-flight
+// refresh workers to drain before returning.
 func (s *Scheduler) Stop() {
 	close(s.stopCh)
 	s.wg.Wait()
@@ -55,13 +104,84 @@ func (s *Scheduler) run() {
 	}
 }
 
+// refresh pulls the feeds that are currently due and fans them out across
+// the worker pool. Each feed carries its own NextUpdate, so most ticks find
+// nothing to do; this replaces the old "refresh everything, serially,
+// every tick" behavior.
 func (s *Scheduler) refresh() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
-	log.Println("starting scheduled feed refresh")
-	if err := s.refreshService.RefreshAll(ctx); err != nil {
-		log.Printf("scheduled refresh error: %v", err)
+	// Stop() must stop this refresh from picking up new work, not just wait
+	// out the 5-minute timeout: tie ctx to stopCh so closing it cancels the
+	// in-flight refresh immediately, leaving only already-dispatched jobs
+	// to drain.
+	go func() {
+		select {
+		case <-s.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	due, err := s.refreshService.DueFeeds(ctx)
+	if err != nil {
+		log.Printf("scheduled refresh: list due feeds: %v", err)
+		return
+	}
+	if len(due) == 0 {
+		return
 	}
+
+	log.Printf("starting scheduled feed refresh: %d feed(s) due", len(due))
+
+	jobs := make(chan model.Feed)
+	var workerWg sync.WaitGroup
+	for i := 0; i < s.workers; i++ {
+		workerWg.Add(1)
+		go func() {
+			defer workerWg.Done()
+			s.worker(ctx, jobs)
+		}()
+	}
+
+dispatch:
+	for _, feed := range due {
+		select {
+		case jobs <- feed:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	workerWg.Wait()
+
 	log.Println("scheduled feed refresh completed")
 }
+
+// worker refreshes feeds from jobs until the channel is closed, waiting on
+// the shared host limiter first so feeds on the same domain are never
+// refreshed in parallel.
+func (s *Scheduler) worker(ctx context.Context, jobs <-chan model.Feed) {
+	for feed := range jobs {
+		if err := s.limiter.Wait(ctx, feed.URL); err != nil {
+			log.Printf("scheduled refresh: feed %d: %v", feed.ID, err)
+			s.emit(RefreshResult{FeedID: feed.ID, Err: err})
+			continue
+		}
+
+		err := s.refreshService.RefreshFeed(ctx, feed.ID)
+		if err != nil {
+			log.Printf("scheduled refresh: feed %d: %v", feed.ID, err)
+		}
+		s.emit(RefreshResult{FeedID: feed.ID, Err: err})
+	}
+}
+
+func (s *Scheduler) emit(result RefreshResult) {
+	select {
+	case s.results <- result:
+	default:
+		// No one is listening; drop rather than block the worker pool.
+	}
+}
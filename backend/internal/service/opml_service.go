@@ -21,10 +21,11 @@ type OPMLService interface {
 }
 
 type ImportResult struct {
-	FoldersCreated int `json:"foldersCreated"`
-	FoldersSkipped int `json:"foldersSkipped"`
-	FeedsCreated   int `json:"feedsCreated"`
-	FeedsSkipped   int `json:"feedsSkipped"`
+	FoldersCreated    int `json:"foldersCreated"`
+	FoldersSkipped    int `json:"foldersSkipped"`
+	FeedsCreated      int `json:"feedsCreated"`
+	FeedsSkipped      int `json:"feedsSkipped"`
+	CategoriesCreated int `json:"categoriesCreated"`
 }
 
 type opmlService struct {
@@ -103,7 +104,7 @@ func importOutline(
 	result *ImportResult,
 ) error {
 	if isFeedOutline(outline) {
-		return importFeed(ctx, outline, parentID, feeds, result)
+		return importFeed(ctx, outline, parentID, folders, feeds, result)
 	}
 
 	folderName := pickOutlineTitle(outline)
@@ -143,10 +144,71 @@ func ensureFolder(ctx context.Context, name string, parentID *int64, folders rep
 	return folder, true, nil
 }
 
+// parseCategoryPaths splits an OPML category attribute (e.g.
+// "/Tech/Go,/Personal") into one path per comma-separated category, each
+// path broken into its "/"-separated folder segments with empty segments
+// dropped.
+func parseCategoryPaths(category string) [][]string {
+	var paths [][]string
+	for _, raw := range strings.Split(category, ",") {
+		var segments []string
+		for _, segment := range strings.Split(raw, "/") {
+			segment = strings.TrimSpace(segment)
+			if segment != "" {
+				segments = append(segments, segment)
+			}
+		}
+		if len(segments) > 0 {
+			paths = append(paths, segments)
+		}
+	}
+	return paths
+}
+
+// ensureFolderPath walks path from the root, creating any folder segment
+// that doesn't already exist, and returns the ID of the deepest (leaf)
+// folder. Newly created segments count toward result.CategoriesCreated
+// rather than result.FoldersCreated, so callers can tell nested-outline
+// folders apart from ones implied by a flat category attribute.
+func ensureFolderPath(ctx context.Context, path []string, folders repository.FolderRepository, result *ImportResult) (*int64, error) {
+	var parentID *int64
+	var ancestors []int64
+	for _, segment := range path {
+		folder, created, err := ensureFolder(ctx, segment, parentID, folders)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			result.CategoriesCreated++
+		}
+		if err := validateNoCycle(folder.ID, ancestors); err != nil {
+			return nil, err
+		}
+		ancestors = append(ancestors, folder.ID)
+		parentID = &folder.ID
+	}
+	return parentID, nil
+}
+
+// validateNoCycle guards against a folder becoming its own ancestor. With
+// ensureFolderPath's strictly top-down walk this can only happen if a
+// segment's name collides with one of its own ancestors, which FindByName
+// (scoped by parent) already prevents from resolving to the wrong row —
+// this is a defensive check against future callers that reparent folders.
+func validateNoCycle(folderID int64, ancestors []int64) error {
+	for _, id := range ancestors {
+		if id == folderID {
+			return fmt.Errorf("invalid folder hierarchy: folder %d cannot be its own ancestor", folderID)
+		}
+	}
+	return nil
+}
+
 func importFeed(
 	ctx context.Context,
 	outline opml.Outline,
 	folderID *int64,
+	folders repository.FolderRepository,
 	feeds repository.FeedRepository,
 	result *ImportResult,
 ) error {
@@ -155,6 +217,19 @@ func importFeed(
 		result.FeedsSkipped++
 		return nil
 	}
+
+	// Flat exports (Feedly, NewsBlur, Inoreader) tag a feed with a
+	// category attribute like "/Tech/Go,/Personal" instead of nesting it
+	// under folder outlines. When present it takes precedence over the
+	// outline's own nesting, and the feed lands in the deepest folder of
+	// the first declared category.
+	if paths := parseCategoryPaths(outline.Category); len(paths) > 0 {
+		resolved, err := ensureFolderPath(ctx, paths[0], folders, result)
+		if err != nil {
+			return err
+		}
+		folderID = resolved
+	}
 	if existing, err := feeds.FindByURL(ctx, feedURL); err != nil {
 		return fmt.Errorf("check feed url: %w", err)
 	} else if existing != nil {
@@ -252,15 +327,18 @@ func buildExportOutlines(folders []model.Folder, feeds []model.Feed) []opml.Outl
 
 	var outlines []opml.Outline
 	for _, node := range roots {
-		outlines = append(outlines, buildFolderOutline(node))
+		outlines = append(outlines, buildFolderOutline(node, nil))
 	}
 	for _, feed := range rootFeeds {
-		outlines = append(outlines, buildFeedOutline(feed))
+		outlines = append(outlines, buildFeedOutline(feed, nil))
 	}
 	return outlines
 }
 
-func buildFolderOutline(node *folderNode) opml.Outline {
+// buildFolderOutline renders node as a nested outline and recurses into its
+// children, threading the folder path down so descendant feeds can also
+// emit a flat category attribute alongside the nesting.
+func buildFolderOutline(node *folderNode, ancestorPath []string) opml.Outline {
 	sort.Slice(node.child, func(i, j int) bool {
 		return strings.ToLower(node.child[i].folder.Name) < strings.ToLower(node.child[j].folder.Name)
 	})
@@ -268,20 +346,26 @@ func buildFolderOutline(node *folderNode) opml.Outline {
 		return strings.ToLower(node.feeds[i].Title) < strings.ToLower(node.feeds[j].Title)
 	})
 
+	path := append(append([]string{}, ancestorPath...), node.folder.Name)
+
 	outline := opml.Outline{
 		Text:  node.folder.Name,
 		Title: node.folder.Name,
 	}
 	for _, child := range node.child {
-		outline.Outlines = append(outline.Outlines, buildFolderOutline(child))
+		outline.Outlines = append(outline.Outlines, buildFolderOutline(child, path))
 	}
 	for _, feed := range node.feeds {
-		outline.Outlines = append(outline.Outlines, buildFeedOutline(feed))
+		outline.Outlines = append(outline.Outlines, buildFeedOutline(feed, path))
 	}
 	return outline
 }
 
-func buildFeedOutline(feed model.Feed) opml.Outline {
+// buildFeedOutline renders feed as a leaf outline. When it sits under a
+// folder, it also gets a "category" attribute with the "/"-joined folder
+// path, so the export imports cleanly into tools (Feedly, NewsBlur,
+// Inoreader) that expect a flat body with categories instead of nesting.
+func buildFeedOutline(feed model.Feed, folderPath []string) opml.Outline {
 	outline := opml.Outline{
 		Text:   feed.Title,
 		Title:  feed.Title,
@@ -291,5 +375,8 @@ func buildFeedOutline(feed model.Feed) opml.Outline {
 	if feed.SiteURL != nil {
 		outline.HTMLURL = *feed.SiteURL
 	}
+	if len(folderPath) > 0 {
+		outline.Category = "/" + strings.Join(folderPath, "/")
+	}
 	return outline
 }
@@ -16,11 +16,18 @@ import (
 	"github.com/microcosm-cc/bluemonday"
 	readability "codeberg.org/readeck/go-readability/v2"
 
+	"gist/backend/internal/cache"
 	"gist/backend/internal/config"
+	"gist/backend/internal/ratelimit"
 	"gist/backend/internal/repository"
 	"gist/backend/internal/service/anubis"
 )
 
+// readableCacheTTL bounds how long a parsed article stays in the in-memory
+// cache. The database row is the durable copy; this just saves a database
+// round-trip for the same entry on the next request within the window.
+const readableCacheTTL = 30 * time.Minute
+
 type ReadabilityService interface {
 	FetchReadableContent(ctx context.Context, entryID int64) (string, error)
 	Close()
@@ -31,9 +38,18 @@ type readabilityService struct {
 	session   *azuretls.Session
 	sanitizer *bluemonday.Policy
 	anubis    *anubis.Solver
+	limiter   *ratelimit.HostLimiter
+	cache     *cache.Cache
 }
 
-func NewReadabilityService(entries repository.EntryRepository, anubisSolver *anubis.Solver) ReadabilityService {
+// NewReadabilityService builds a ReadabilityService. limiter is shared with
+// the scheduler's feed fetcher so a host being polled for new entries and a
+// user opening an article from that same host never hit it in parallel.
+// contentCache holds recently parsed articles in memory so re-opening an
+// entry is served without reading its stored content back from the
+// database; it is typically shared across services so they draw from one
+// process-wide memory budget.
+func NewReadabilityService(entries repository.EntryRepository, anubisSolver *anubis.Solver, limiter *ratelimit.HostLimiter, contentCache *cache.Cache) ReadabilityService {
 	// Create a sanitizer policy similar to DOMPurify
 	// This removes scripts and other elements that interfere with readability parsing
 	p := bluemonday.UGCPolicy()
@@ -50,10 +66,19 @@ func NewReadabilityService(entries repository.EntryRepository, anubisSolver *anu
 		session:   session,
 		sanitizer: p,
 		anubis:    anubisSolver,
+		limiter:   limiter,
+		cache:     contentCache,
 	}
 }
 
 func (s *readabilityService) FetchReadableContent(ctx context.Context, entryID int64) (string, error) {
+	cacheKey := readableCacheKey(entryID)
+	if s.cache != nil {
+		if cached, ok := s.cache.Get(cacheKey); ok {
+			return cached.(string), nil
+		}
+	}
+
 	entry, err := s.entries.GetByID(ctx, entryID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -64,6 +89,7 @@ func (s *readabilityService) FetchReadableContent(ctx context.Context, entryID i
 
 	// Return cached content if available
 	if entry.ReadableContent != nil && *entry.ReadableContent != "" {
+		s.cacheContent(cacheKey, *entry.ReadableContent)
 		return *entry.ReadableContent, nil
 	}
 
@@ -110,6 +136,7 @@ func (s *readabilityService) FetchReadableContent(ctx context.Context, entryID i
 	if err := s.entries.UpdateReadableContent(ctx, entryID, content); err != nil {
 		return "", err
 	}
+	s.cacheContent(cacheKey, content)
 
 	return content, nil
 }
@@ -121,6 +148,17 @@ func (s *readabilityService) Close() {
 	}
 }
 
+func (s *readabilityService) cacheContent(key, content string) {
+	if s.cache == nil {
+		return
+	}
+	s.cache.Set(key, content, len(content), readableCacheTTL)
+}
+
+func readableCacheKey(entryID int64) string {
+	return fmt.Sprintf("readable:%d", entryID)
+}
+
 // fetchWithChrome fetches URL with Chrome TLS fingerprint and browser headers
 func (s *readabilityService) fetchWithChrome(ctx context.Context, targetURL string, cookie string) ([]byte, error) {
 	parsedURL, err := url.Parse(targetURL)
@@ -133,6 +171,14 @@ func (s *readabilityService) fetchWithChrome(ctx context.Context, targetURL stri
 		return nil, ErrInvalid
 	}
 
+	// Respect the shared per-host rate limit before doing any work, so this
+	// host doesn't get hit back-to-back by a feed refresh and an article fetch.
+	if s.limiter != nil {
+		if err := s.limiter.Wait(ctx, targetURL); err != nil {
+			return nil, err
+		}
+	}
+
 	// Build ordered headers matching Chrome 135
 	headers := azuretls.OrderedHeaders{
 		{"accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8,application/signed-exchange;v=b3;q=0.7"},
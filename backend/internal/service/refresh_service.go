@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"gist/backend/internal/model"
+	"gist/backend/internal/notifier"
+	"gist/backend/internal/repository"
+	"gist/backend/internal/websub"
+)
+
+// maxBackoff caps how far a feed's NextUpdate can be pushed out after
+// repeated failures, so a long-dead host is still retried occasionally.
+const maxBackoff = 168 * time.Hour
+
+type RefreshService interface {
+	// DueFeeds returns feeds whose NextUpdate has passed.
+	DueFeeds(ctx context.Context) ([]model.Feed, error)
+	// RefreshFeed fetches a single feed, ingests new entries, and updates
+	// its NextUpdate/error bookkeeping accordingly.
+	RefreshFeed(ctx context.Context, feedID int64) error
+	// RefreshAll refreshes every feed that is currently due. Kept for
+	// callers (and tests) that want a one-shot sweep rather than the
+	// scheduler's pull loop.
+	RefreshAll(ctx context.Context) error
+}
+
+type refreshService struct {
+	feeds      repository.FeedRepository
+	entries    repository.EntryRepository
+	fetcher    FeedFetcher
+	interval   time.Duration
+	dispatcher *notifier.Dispatcher
+	websub     *websub.Manager
+}
+
+// FeedFetcher fetches and parses a feed document, returning both the
+// entries it contains and the raw document body. The raw body is kept
+// around (rather than discarded after parsing) so callers can also look
+// for WebSub hub/self links without a second round-trip. Implemented
+// elsewhere (the feed-parsing package); declared here so refreshService can
+// be constructed without a hard dependency on it.
+type FeedFetcher interface {
+	Fetch(ctx context.Context, feedURL string) (entries []model.Entry, raw []byte, err error)
+}
+
+// NewRefreshService builds a RefreshService. interval is the default refresh
+// interval used for feeds that don't declare their own. dispatcher and
+// websubMgr may be nil, in which case notification and WebSub subscription
+// are simply skipped.
+func NewRefreshService(feeds repository.FeedRepository, entries repository.EntryRepository, fetcher FeedFetcher, interval time.Duration, dispatcher *notifier.Dispatcher, websubMgr *websub.Manager) RefreshService {
+	return &refreshService{feeds: feeds, entries: entries, fetcher: fetcher, interval: interval, dispatcher: dispatcher, websub: websubMgr}
+}
+
+func (s *refreshService) DueFeeds(ctx context.Context) ([]model.Feed, error) {
+	feeds, err := s.feeds.DueForRefresh(ctx, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("list due feeds: %w", err)
+	}
+	return feeds, nil
+}
+
+func (s *refreshService) RefreshAll(ctx context.Context) error {
+	feeds, err := s.DueFeeds(ctx)
+	if err != nil {
+		return err
+	}
+	for _, feed := range feeds {
+		if err := s.RefreshFeed(ctx, feed.ID); err != nil {
+			log.Printf("refresh feed %d: %v", feed.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *refreshService) RefreshFeed(ctx context.Context, feedID int64) error {
+	feed, err := s.feeds.GetByID(ctx, feedID)
+	if err != nil {
+		return fmt.Errorf("get feed: %w", err)
+	}
+
+	entries, raw, fetchErr := s.fetcher.Fetch(ctx, feed.URL)
+	if fetchErr != nil {
+		return s.recordFailure(ctx, feed, fetchErr)
+	}
+
+	if len(entries) > 0 {
+		if err := s.entries.InsertNew(ctx, feed.ID, entries); err != nil {
+			return fmt.Errorf("insert entries: %w", err)
+		}
+		if s.dispatcher != nil {
+			s.dispatcher.Enqueue(ctx, feed, entries)
+		}
+	}
+
+	s.maybeSubscribeWebSub(ctx, feed, raw)
+
+	return s.recordSuccess(ctx, feed)
+}
+
+// recordSuccess schedules the next run from the feed's own interval (falling
+// back to the scheduler default) and resets the error counter.
+func (s *refreshService) recordSuccess(ctx context.Context, feed model.Feed) error {
+	interval := s.interval
+	if feed.RefreshInterval != nil && *feed.RefreshInterval > 0 {
+		interval = *feed.RefreshInterval
+	}
+	next := time.Now().Add(interval)
+	if err := s.feeds.UpdateSchedule(ctx, feed.ID, next, 0); err != nil {
+		return fmt.Errorf("update schedule: %w", err)
+	}
+	return nil
+}
+
+// recordFailure increments the feed's error counter and pushes NextUpdate
+// out by errors hours, capped at maxBackoff. The first error after a healthy
+// run (errors going from 0 to 1) is treated as a transient blip and doesn't
+// delay the feed, so a single failed fetch doesn't cost an hour.
+func (s *refreshService) recordFailure(ctx context.Context, feed model.Feed, fetchErr error) error {
+	errors := feed.ErrorCount + 1
+
+	var delay time.Duration
+	if feed.ErrorCount > 0 {
+		delay = time.Duration(errors) * time.Hour
+		if delay > maxBackoff {
+			delay = maxBackoff
+		}
+	}
+
+	next := time.Now().Add(delay)
+	if err := s.feeds.UpdateSchedule(ctx, feed.ID, next, errors); err != nil {
+		return fmt.Errorf("update schedule after failure: %w", err)
+	}
+	return fmt.Errorf("fetch feed: %w", fetchErr)
+}
+
+// maybeSubscribeWebSub looks for hub/self links in a freshly-fetched feed
+// document and subscribes the feed to push updates if a hub is advertised
+// and we haven't already subscribed. Polling still covers the feed via its
+// normal NextUpdate schedule, so a subscribe failure here is logged and
+// otherwise ignored.
+func (s *refreshService) maybeSubscribeWebSub(ctx context.Context, feed model.Feed, raw []byte) {
+	if s.websub == nil || len(raw) == 0 || feed.WebSubHub != nil {
+		return
+	}
+
+	links := websub.DiscoverLinks(raw)
+	if links.Hub == "" {
+		return
+	}
+	topic := links.Self
+	if topic == "" {
+		topic = feed.URL
+	}
+
+	if err := s.websub.Subscribe(ctx, feed.ID, links.Hub, topic); err != nil {
+		log.Printf("websub: subscribe feed %d to %s: %v", feed.ID, links.Hub, err)
+	}
+}
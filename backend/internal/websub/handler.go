@@ -0,0 +1,151 @@
+package websub
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // WebSub's signature scheme mandates sha1
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gist/backend/internal/repository"
+)
+
+// IngestFunc parses a pushed feed body for feedID and runs it through the
+// same entry-ingestion path the scheduler uses, returning the number of
+// new entries inserted. It's injected rather than imported directly so
+// this package doesn't need to depend on the feed-parsing/service layer.
+type IngestFunc func(feedID int64, body []byte) (int, error)
+
+// Handler answers a hub's challenge verification GETs and accepts its
+// signed push POSTs, one callback path per feed
+// ("<publicBase>/websub/<feedID>").
+type Handler struct {
+	feeds  repository.FeedRepository
+	ingest IngestFunc
+}
+
+// NewHandler builds a Handler. ingest is called for every verified push.
+func NewHandler(feeds repository.FeedRepository, ingest IngestFunc) *Handler {
+	return &Handler{feeds: feeds, ingest: ingest}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	feedID, ok := feedIDFromPath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.handleVerify(w, r, feedID)
+	case http.MethodPost:
+		h.handlePush(w, r, feedID)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// handleVerify answers the hub's subscription/unsubscription challenge by
+// echoing hub.challenge back, once the mode and topic match a subscription
+// we actually have recorded for this feed.
+func (h *Handler) handleVerify(w http.ResponseWriter, r *http.Request, feedID int64) {
+	query := r.URL.Query()
+	mode := query.Get("hub.mode")
+	topic := query.Get("hub.topic")
+	challenge := query.Get("hub.challenge")
+
+	if mode == "" || challenge == "" {
+		http.Error(w, "missing hub.mode or hub.challenge", http.StatusBadRequest)
+		return
+	}
+
+	sub, err := h.feeds.GetWebSub(r.Context(), feedID)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if mode != "subscribe" && mode != "unsubscribe" {
+		http.Error(w, "unsupported hub.mode", http.StatusBadRequest)
+		return
+	}
+	if topic != sub.Topic {
+		http.Error(w, "topic mismatch", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(challenge))
+}
+
+// handlePush verifies the X-Hub-Signature header against the feed's
+// stored secret and, if it matches, hands the body to ingest.
+func (h *Handler) handlePush(w http.ResponseWriter, r *http.Request, feedID int64) {
+	sub, err := h.feeds.GetWebSub(r.Context(), feedID)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 10<<20))
+	if err != nil {
+		http.Error(w, "read body", http.StatusBadRequest)
+		return
+	}
+
+	signature := r.Header.Get("X-Hub-Signature")
+	if !verifySignature(signature, sub.Secret, body) {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+
+	// The hub expects a fast 2xx; ingestion runs before we respond since a
+	// 202 doesn't retry on our side if it then fails, but this keeps the
+	// implementation simple and pushes are already small deltas.
+	n, err := h.ingest(feedID, body)
+	if err != nil {
+		log.Printf("websub: ingest push for feed %d: %v", feedID, err)
+		http.Error(w, "ingest failed", http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("websub: feed %d received push, %d new entr(y/ies)", feedID, n)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func verifySignature(header, secret string, body []byte) bool {
+	if secret == "" {
+		// No recorded subscription secret means we never actually
+		// subscribed this feed; never treat an unsigned or arbitrarily
+		// signed push as valid.
+		return false
+	}
+
+	const prefix = "sha1="
+	if !strings.HasPrefix(header, prefix) {
+		return false
+	}
+	got, err := hex.DecodeString(strings.TrimPrefix(header, prefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+func feedIDFromPath(path string) (int64, bool) {
+	idx := strings.LastIndex(path, "/")
+	if idx < 0 {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(path[idx+1:], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
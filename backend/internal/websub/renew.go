@@ -0,0 +1,52 @@
+package websub
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gist/backend/internal/repository"
+)
+
+// renewCheckInterval is how often the renewal loop looks for subscriptions
+// approaching expiry.
+const renewCheckInterval = time.Hour
+
+// RunRenewals starts a blocking loop that renews WebSub subscriptions
+// before their lease expires, and falls back to polling (by simply leaving
+// the feed's NextUpdate-driven poll schedule untouched) when the hub stops
+// responding to renewal attempts. It returns when ctx is done.
+func (m *Manager) RunRenewals(ctx context.Context) {
+	ticker := time.NewTicker(renewCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.renewExpiring(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (m *Manager) renewExpiring(ctx context.Context) {
+	expiring, err := m.feeds.DueForWebSubRenewal(ctx, time.Now().Add(renewBefore))
+	if err != nil {
+		log.Printf("websub: list expiring subscriptions: %v", err)
+		return
+	}
+
+	for _, sub := range expiring {
+		if err := m.renewOne(ctx, sub); err != nil {
+			// Renewal failures are not fatal: the feed keeps its existing
+			// NextUpdate schedule from the scheduler, so it's still
+			// polled even if push delivery has stopped working.
+			log.Printf("websub: renew feed %d: %v (falling back to polling)", sub.FeedID, err)
+		}
+	}
+}
+
+func (m *Manager) renewOne(ctx context.Context, sub repository.WebSubSubscription) error {
+	return m.Subscribe(ctx, sub.FeedID, sub.Hub, sub.Topic)
+}
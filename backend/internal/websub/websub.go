@@ -0,0 +1,146 @@
+// Package websub implements the subscriber side of WebSub (formerly
+// PubSubHubbub): it discovers hub/self links in a fetched feed, subscribes
+// to the hub so new entries push in near real time instead of waiting for
+// the next poll, and renews the subscription before its lease expires.
+package websub
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"gist/backend/internal/repository"
+)
+
+// defaultLease is the lease length assumed when recording a subscription's
+// expiry; it is not sent to the hub, and the lease the hub actually grants is
+// not read back. Most hubs (Superfeedr, Google's legacy hub) default to
+// around this.
+const defaultLease = 10 * 24 * time.Hour
+
+// renewBefore is how far ahead of lease expiry a subscription is renewed.
+const renewBefore = 24 * time.Hour
+
+var (
+	hubLinkRe  = regexp.MustCompile(`(?i)<(?:\w+:)?link[^>]*rel=["']hub["'][^>]*href=["']([^"']+)["']`)
+	selfLinkRe = regexp.MustCompile(`(?i)<(?:\w+:)?link[^>]*rel=["']self["'][^>]*href=["']([^"']+)["']`)
+)
+
+// Links holds the hub and self URLs discovered in a feed document, if any.
+type Links struct {
+	Hub  string
+	Self string
+}
+
+// DiscoverLinks scans a feed document's raw bytes for <link rel="hub"> and
+// <link rel="self"> elements. It works directly on the raw bytes rather
+// than a parsed feed struct so it doesn't need to know the feed format
+// (RSS vs Atom put these links in different places but both render as
+// plain <link> elements with a rel attribute).
+func DiscoverLinks(body []byte) Links {
+	var links Links
+	if m := hubLinkRe.FindSubmatch(body); m != nil {
+		links.Hub = strings.TrimSpace(string(m[1]))
+	}
+	if m := selfLinkRe.FindSubmatch(body); m != nil {
+		links.Self = strings.TrimSpace(string(m[1]))
+	}
+	return links
+}
+
+// Manager subscribes feeds to their hub and keeps the subscription alive.
+type Manager struct {
+	feeds      repository.FeedRepository
+	httpClient *http.Client
+	publicBase string // e.g. "https://gist.example.com"
+}
+
+// NewManager builds a Manager. publicBase is this instance's externally
+// reachable base URL, used to build each feed's callback URL
+// (<publicBase>/websub/<feedID>).
+func NewManager(feeds repository.FeedRepository, publicBase string) *Manager {
+	return &Manager{
+		feeds:      feeds,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		publicBase: strings.TrimRight(publicBase, "/"),
+	}
+}
+
+// Subscribe sends a subscription request to hubURL for topicURL on behalf
+// of feedID, generating a fresh per-feed secret and persisting the hub
+// URL, secret, and expected lease expiry so the callback handler and the
+// renewal loop can find it again.
+func (m *Manager) Subscribe(ctx context.Context, feedID int64, hubURL, topicURL string) error {
+	secret, err := newSecret()
+	if err != nil {
+		return fmt.Errorf("websub: generate secret: %w", err)
+	}
+
+	if err := m.sendSubscription(ctx, feedID, hubURL, topicURL, secret, "subscribe"); err != nil {
+		return err
+	}
+
+	// The hub confirms asynchronously via a GET challenge, but we record
+	// the hub/topic/secret/expected lease now so the callback handler has
+	// something to verify the challenge and push signature against, and so
+	// renewal can resubscribe with the same topic later.
+	leaseExpiry := time.Now().Add(defaultLease)
+	if err := m.feeds.UpdateWebSub(ctx, feedID, hubURL, topicURL, secret, leaseExpiry); err != nil {
+		return fmt.Errorf("websub: persist subscription: %w", err)
+	}
+	return nil
+}
+
+// Unsubscribe tells the hub to stop delivering updates for topicURL.
+func (m *Manager) Unsubscribe(ctx context.Context, feedID int64, hubURL, topicURL, secret string) error {
+	return m.sendSubscription(ctx, feedID, hubURL, topicURL, secret, "unsubscribe")
+}
+
+func (m *Manager) sendSubscription(ctx context.Context, feedID int64, hubURL, topicURL, secret, mode string) error {
+	form := url.Values{
+		"hub.mode":     {mode},
+		"hub.topic":    {topicURL},
+		"hub.callback": {m.callbackURL(feedID)},
+		"hub.secret":   {secret},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hubURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("websub: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("websub: hub request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Per spec a hub should return 202 Accepted; be lenient and accept any
+	// 2xx since some hubs return 200/204 instead.
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("websub: hub returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// callbackURL builds this feed's push callback. It's keyed by feed ID
+// rather than topic so the handler can look the subscription up without
+// also needing to know the topic URL the hub will send it back.
+func (m *Manager) callbackURL(feedID int64) string {
+	return fmt.Sprintf("%s/websub/%d", m.publicBase, feedID)
+}
+
+func newSecret() (string, error) {
+	buf := make([]byte, 20)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}